@@ -0,0 +1,45 @@
+// Package embedder abstracts the embedding provider so repositories can
+// switch between Ollama, OpenAI, and local models without touching the
+// storage or search code.
+package embedder
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// Embedder turns text into an embedding vector.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+	// Dimension is the length of the vectors Embed returns.
+	Dimension() int
+	// Model identifies the specific model in use, e.g. "nomic-embed-text".
+	Model() string
+	// Provider identifies the backing service, e.g. "ollama" or "openai".
+	Provider() string
+}
+
+// NewFromEnv builds the Embedder selected by the EMBEDDING_PROVIDER env var
+// ("ollama", the default; "openai"; or "local").
+func NewFromEnv() (Embedder, error) {
+	provider := os.Getenv("EMBEDDING_PROVIDER")
+	if provider == "" {
+		provider = "ollama"
+	}
+
+	switch provider {
+	case "ollama":
+		return NewOllamaEmbedder(), nil
+	case "openai":
+		apiKey := os.Getenv("OPENAI_API_KEY")
+		if apiKey == "" {
+			return nil, fmt.Errorf("embedder: OPENAI_API_KEY is required for the openai provider")
+		}
+		return NewOpenAIEmbedder(apiKey), nil
+	case "local":
+		return NewLocalEmbedder(), nil
+	default:
+		return nil, fmt.Errorf("embedder: unknown EMBEDDING_PROVIDER %q", provider)
+	}
+}
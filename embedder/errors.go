@@ -0,0 +1,44 @@
+package embedder
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// StatusError is returned by an Embedder when the backing HTTP service
+// responds with a non-200 status, so callers can distinguish a permanent
+// rejection (4xx) from a transient server-side failure (5xx/429).
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("embedding service returned status: %d", e.StatusCode)
+}
+
+// TerminalError marks an error as permanent: retrying it can never
+// succeed, e.g. a provider stub that isn't implemented yet. embedWithRetry
+// checks for it via Transient to avoid wasting a backoff sequence on it.
+type TerminalError struct {
+	Err error
+}
+
+func (e *TerminalError) Error() string { return e.Err.Error() }
+func (e *TerminalError) Unwrap() error { return e.Err }
+
+// Transient reports whether err, as returned from Embed, is worth
+// retrying. A *TerminalError, or a *StatusError in the 4xx range, is not;
+// a *StatusError in the 5xx/429 range, or any other error (e.g. a network
+// failure), is.
+func Transient(err error) bool {
+	var terminal *TerminalError
+	if errors.As(err, &terminal) {
+		return false
+	}
+	var status *StatusError
+	if errors.As(err, &status) {
+		return status.StatusCode >= 500 || status.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
@@ -0,0 +1,41 @@
+package embedder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// LocalEmbedder is a stub for an embedder backed by a local model (e.g.
+// llama.cpp). It reports its configured model/dimension so documents can
+// record them up front, but Embed is not yet implemented.
+type LocalEmbedder struct {
+	modelPath string
+	dim       int
+}
+
+// NewLocalEmbedder builds a LocalEmbedder from LOCAL_EMBED_MODEL_PATH and
+// LOCAL_EMBED_DIM, defaulting to a dimension of 768.
+func NewLocalEmbedder() *LocalEmbedder {
+	dim, err := strconv.Atoi(os.Getenv("LOCAL_EMBED_DIM"))
+	if err != nil || dim <= 0 {
+		dim = 768
+	}
+	return &LocalEmbedder{modelPath: os.Getenv("LOCAL_EMBED_MODEL_PATH"), dim: dim}
+}
+
+func (e *LocalEmbedder) Provider() string { return "local" }
+
+func (e *LocalEmbedder) Model() string {
+	if e.modelPath == "" {
+		return "local"
+	}
+	return e.modelPath
+}
+
+func (e *LocalEmbedder) Dimension() int { return e.dim }
+
+func (e *LocalEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	return nil, &TerminalError{Err: fmt.Errorf("embedder: local model provider is not implemented yet (set EMBEDDING_PROVIDER=ollama or openai)")}
+}
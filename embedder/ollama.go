@@ -0,0 +1,100 @@
+package embedder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+// OllamaEmbedder calls a local or remote Ollama server's /api/embeddings
+// endpoint. It is this project's original embedding implementation.
+type OllamaEmbedder struct {
+	url   string
+	model string
+	dim   int
+}
+
+// NewOllamaEmbedder builds an OllamaEmbedder from OLLAMA_URL,
+// OLLAMA_EMBED_MODEL, and OLLAMA_EMBED_DIM, defaulting to
+// "nomic-embed-text" and a dimension of 768.
+func NewOllamaEmbedder() *OllamaEmbedder {
+	model := os.Getenv("OLLAMA_EMBED_MODEL")
+	if model == "" {
+		model = "nomic-embed-text"
+	}
+	dim, err := strconv.Atoi(os.Getenv("OLLAMA_EMBED_DIM"))
+	if err != nil || dim <= 0 {
+		dim = 768
+	}
+	return &OllamaEmbedder{url: getOllamaURL(), model: model, dim: dim}
+}
+
+func getOllamaURL() string {
+	ollamaURL := os.Getenv("OLLAMA_URL")
+	if ollamaURL != "" {
+		return ollamaURL
+	}
+	// Detect Docker environment
+	if _, exists := os.LookupEnv("DOCKER_CONTAINER"); exists {
+		return "http://host.docker.internal:11434"
+	}
+	return "http://localhost:11434"
+}
+
+func (e *OllamaEmbedder) Provider() string { return "ollama" }
+func (e *OllamaEmbedder) Model() string    { return e.model }
+func (e *OllamaEmbedder) Dimension() int   { return e.dim }
+
+func (e *OllamaEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	ollamaReq := map[string]string{
+		"model":  e.model,
+		"prompt": text,
+	}
+	requestBody, err := json.Marshal(ollamaReq)
+	if err != nil {
+		log.Printf("Failed to marshal Ollama request: %v", err)
+		return nil, fmt.Errorf("failed to prepare embedding request: %v", err)
+	}
+
+	endpoint := e.url + "/api/embeddings"
+	log.Printf("Sending request to Ollama: %s, URL: %s", string(requestBody), endpoint)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build embedding request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.Printf("Ollama connection error: %v", err)
+		return nil, fmt.Errorf("failed to connect to embedding service: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.Printf("Error reading Ollama response: %v", err)
+		return nil, fmt.Errorf("failed to read embedding response: %v", err)
+	}
+	log.Printf("Ollama response: status=%d, body=%s", resp.StatusCode, string(body))
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("Ollama returned non-200 status: %d", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	var ollamaResp struct {
+		Embedding []float32 `json:"embedding"`
+	}
+	if err := json.Unmarshal(body, &ollamaResp); err != nil {
+		log.Printf("Failed to parse Ollama response: %v", err)
+		return nil, fmt.Errorf("failed to parse embedding response: %v", err)
+	}
+	return ollamaResp.Embedding, nil
+}
@@ -0,0 +1,89 @@
+package embedder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+const openAIEmbeddingsURL = "https://api.openai.com/v1/embeddings"
+
+// OpenAIEmbedder calls OpenAI's /v1/embeddings endpoint.
+type OpenAIEmbedder struct {
+	apiKey string
+	model  string
+	dim    int
+}
+
+// NewOpenAIEmbedder builds an OpenAIEmbedder from OPENAI_EMBED_MODEL and
+// OPENAI_EMBED_DIM, defaulting to "text-embedding-3-small" and a dimension
+// of 1536.
+func NewOpenAIEmbedder(apiKey string) *OpenAIEmbedder {
+	model := os.Getenv("OPENAI_EMBED_MODEL")
+	if model == "" {
+		model = "text-embedding-3-small"
+	}
+	dim, err := strconv.Atoi(os.Getenv("OPENAI_EMBED_DIM"))
+	if err != nil || dim <= 0 {
+		dim = 1536
+	}
+	return &OpenAIEmbedder{apiKey: apiKey, model: model, dim: dim}
+}
+
+func (e *OpenAIEmbedder) Provider() string { return "openai" }
+func (e *OpenAIEmbedder) Model() string    { return e.model }
+func (e *OpenAIEmbedder) Dimension() int   { return e.dim }
+
+func (e *OpenAIEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	reqBody := map[string]interface{}{
+		"model": e.model,
+		"input": text,
+	}
+	requestBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare embedding request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, openAIEmbeddingsURL, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build embedding request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+e.apiKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.Printf("OpenAI connection error: %v", err)
+		return nil, fmt.Errorf("failed to connect to embedding service: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedding response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("OpenAI returned non-200 status: %d, body=%s", resp.StatusCode, string(body))
+		return nil, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	var openAIResp struct {
+		Data []struct {
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &openAIResp); err != nil {
+		return nil, fmt.Errorf("failed to parse embedding response: %v", err)
+	}
+	if len(openAIResp.Data) == 0 {
+		return nil, fmt.Errorf("embedding response contained no data")
+	}
+	return openAIResp.Data[0].Embedding, nil
+}
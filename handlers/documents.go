@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"golang_crud/models"
+	"golang_crud/repositories"
+)
+
+// BatchCreateDocuments returns a handler for POST /documents/batch. It
+// accepts a JSON array of documents, embeds and saves them through
+// repositories.CreateDocumentsBatch, and responds with the same documents,
+// those that were saved now carrying IDs. The status is 201 Created on
+// success and 207 Multi-Status if CreateDocumentsBatch returned an error;
+// the response body holds no per-document error details.
+func BatchCreateDocuments(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var docs []*models.Document
+		if err := json.NewDecoder(r.Body).Decode(&docs); err != nil {
+			log.Printf("Failed to decode batch request: %v", err)
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		err := repositories.CreateDocumentsBatch(db, docs)
+		if err != nil {
+			log.Printf("CreateDocumentsBatch error: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err != nil {
+			w.WriteHeader(http.StatusMultiStatus)
+		} else {
+			w.WriteHeader(http.StatusCreated)
+		}
+		json.NewEncoder(w).Encode(docs)
+	}
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"golang_crud/models"
+	"golang_crud/repositories"
+)
+
+// CreateDocumentStream returns a handler for POST /documents/stream. It
+// decodes a document from the request body, ingests it via
+// repositories.CreateDocumentWithProgress, and streams each stage
+// transition to the client as a Server-Sent Event.
+func CreateDocumentStream(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		var doc models.Document
+		if err := json.NewDecoder(r.Body).Decode(&doc); err != nil {
+			log.Printf("Failed to decode document: %v", err)
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		progressCh := make(chan repositories.Progress)
+		done := make(chan error, 1)
+		go func() {
+			done <- repositories.CreateDocumentWithProgress(db, &doc, progressCh)
+		}()
+
+		for p := range progressCh {
+			data, err := json.Marshal(p)
+			if err != nil {
+				log.Printf("Failed to marshal progress event: %v", err)
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", p.Stage, data)
+			flusher.Flush()
+		}
+
+		if err := <-done; err != nil {
+			log.Printf("CreateDocumentWithProgress error: %v", err)
+		}
+	}
+}
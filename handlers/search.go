@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"golang_crud/repositories"
+)
+
+const defaultSearchLimit = 10
+
+// SearchDocuments returns a handler for GET /documents/search?q=...&mode=...&limit=...
+// mode is one of "vector" (default), "text", or "hybrid".
+func SearchDocuments(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		queryText := r.URL.Query().Get("q")
+		if queryText == "" {
+			http.Error(w, "missing q parameter", http.StatusBadRequest)
+			return
+		}
+
+		mode := r.URL.Query().Get("mode")
+		if mode == "" {
+			mode = "vector"
+		}
+
+		limit := defaultSearchLimit
+		if raw := r.URL.Query().Get("limit"); raw != "" {
+			if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+				limit = n
+			}
+		}
+
+		var queryEmbedding []float32
+		if mode != "text" {
+			e, err := repositories.GetEmbedder()
+			if err != nil {
+				log.Printf("Failed to get embedder: %v", err)
+				http.Error(w, "embedder unavailable", http.StatusInternalServerError)
+				return
+			}
+			embedding, err := e.Embed(r.Context(), queryText)
+			if err != nil {
+				log.Printf("Failed to embed query: %v", err)
+				http.Error(w, "failed to embed query", http.StatusInternalServerError)
+				return
+			}
+			queryEmbedding = embedding
+		}
+
+		docs, err := repositories.SearchSimilarDocuments(db, queryText, queryEmbedding, mode, limit)
+		if err != nil {
+			log.Printf("SearchSimilarDocuments error: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(docs)
+	}
+}
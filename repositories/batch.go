@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang_crud/embedder"
+	"golang_crud/models"
+	"golang_crud/vectorstore"
+)
+
+const (
+	defaultBatchConcurrency = 4
+	defaultBatchMaxRetries  = 3
+)
+
+// getBatchConcurrency reads BATCH_EMBED_CONCURRENCY, falling back to
+// defaultBatchConcurrency for unset or invalid values.
+func getBatchConcurrency() int {
+	n, err := strconv.Atoi(os.Getenv("BATCH_EMBED_CONCURRENCY"))
+	if err != nil || n <= 0 {
+		return defaultBatchConcurrency
+	}
+	return n
+}
+
+// embedWithRetry calls e.Embed, retrying transient failures (network
+// errors, 5xx/429 responses) up to maxRetries times with exponential
+// backoff (1s, 2s, 4s, ...). A terminal failure -- a 4xx response, or an
+// unimplemented provider like LocalEmbedder -- is returned immediately
+// since retrying it can't change the outcome.
+func embedWithRetry(e embedder.Embedder, text string, maxRetries int) ([]float32, error) {
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			backoff := time.Duration(1<<uint(attempt-1)) * time.Second
+			log.Printf("Retrying embedding in %v (attempt %d/%d) after error: %v", backoff, attempt, maxRetries, lastErr)
+			time.Sleep(backoff)
+		}
+
+		embedding, err := e.Embed(context.Background(), text)
+		if err == nil {
+			return embedding, nil
+		}
+		if !embedder.Transient(err) {
+			return nil, fmt.Errorf("embedding failed permanently: %v", err)
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("embedding failed after %d attempts: %v", maxRetries+1, lastErr)
+}
+
+// CreateDocumentsBatch embeds docs through a bounded worker pool (sized by
+// BATCH_EMBED_CONCURRENCY), retrying transient embedding failures with
+// exponential backoff, then saves every successfully embedded document --
+// in a single transaction when the backend implements
+// vectorstore.BatchStore, otherwise with one Save call per document.
+// Documents that fail to embed are skipped and logged;
+// an error is returned if any document failed, but successfully embedded
+// documents are still saved.
+//
+// Batch-saved documents are never split into chunks (that happens only in
+// CreateDocument's single-document path), but they're still reachable by
+// the default vector search -- SearchChunks falls back to scoring
+// un-chunked parents directly.
+func CreateDocumentsBatch(db *sql.DB, docs []*models.Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	e, err := GetEmbedder()
+	if err != nil {
+		return fmt.Errorf("failed to get embedder: %v", err)
+	}
+
+	concurrency := getBatchConcurrency()
+	jobs := make(chan int)
+	errs := make([]error, len(docs))
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				embedding, err := embedWithRetry(e, docs[i].Content, defaultBatchMaxRetries)
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+				docs[i].Embedding = embedding
+				stampEmbeddingMetadata(docs[i], e)
+			}
+		}()
+	}
+
+	for i := range docs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	succeeded := make([]*models.Document, 0, len(docs))
+	failed := 0
+	for i, doc := range docs {
+		if errs[i] != nil {
+			log.Printf("Failed to embed document %d: %v", i, errs[i])
+			failed++
+			continue
+		}
+		succeeded = append(succeeded, doc)
+	}
+
+	if len(succeeded) > 0 {
+		vs, err := GetVectorStore(db)
+		if err != nil {
+			return fmt.Errorf("failed to get vector store: %v", err)
+		}
+
+		if batchStore, ok := vs.(vectorstore.BatchStore); ok {
+			if err := batchStore.SaveBatch(succeeded); err != nil {
+				return fmt.Errorf("failed to save document batch: %v", err)
+			}
+		} else {
+			for _, doc := range succeeded {
+				if err := vs.Save(doc); err != nil {
+					return fmt.Errorf("failed to save document: %v", err)
+				}
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d documents failed to embed", failed, len(docs))
+	}
+	return nil
+}
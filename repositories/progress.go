@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"golang_crud/models"
+	"golang_crud/vectorstore"
+)
+
+// Progress is one stage transition emitted while CreateDocumentWithProgress
+// ingests a document, modeled after the status/completed/total fields
+// Ollama's own pull/create commands stream.
+type Progress struct {
+	// Stage is one of "queued", "embedding", "chunk", "persisting", "done",
+	// or "error".
+	Stage string `json:"stage"`
+	// Chunk and Total are set when Stage is "chunk", 1-indexed.
+	Chunk int `json:"chunk,omitempty"`
+	Total int `json:"total,omitempty"`
+	// Error is set when Stage is "error".
+	Error string `json:"error,omitempty"`
+}
+
+// CreateDocumentWithProgress behaves like CreateDocument but reports each
+// stage transition on progressCh, which it closes before returning. This
+// lets a frontend progress bar, or a test asserting on stage transitions,
+// observe ingestion of a large document instead of only seeing the final
+// result from an opaque log.Printf trail.
+func CreateDocumentWithProgress(db *sql.DB, doc *models.Document, progressCh chan<- Progress) error {
+	defer close(progressCh)
+
+	progressCh <- Progress{Stage: "queued"}
+
+	vs, err := GetVectorStore(db)
+	if err != nil {
+		progressCh <- Progress{Stage: "error", Error: err.Error()}
+		return fmt.Errorf("failed to get vector store: %v", err)
+	}
+	e, err := GetEmbedder()
+	if err != nil {
+		progressCh <- Progress{Stage: "error", Error: err.Error()}
+		return fmt.Errorf("failed to get embedder: %v", err)
+	}
+	stampEmbeddingMetadata(doc, e)
+
+	pieces := getSplitter().Split(doc.Content)
+	chunkedStore, supportsChunks := vs.(vectorstore.ChunkedStore)
+
+	if !supportsChunks || len(pieces) <= 1 {
+		progressCh <- Progress{Stage: "embedding"}
+		embedding, err := e.Embed(context.Background(), doc.Content)
+		if err != nil {
+			progressCh <- Progress{Stage: "error", Error: err.Error()}
+			return err
+		}
+		doc.Embedding = embedding
+
+		progressCh <- Progress{Stage: "persisting"}
+		if err := vs.Save(doc); err != nil {
+			progressCh <- Progress{Stage: "error", Error: err.Error()}
+			return err
+		}
+
+		progressCh <- Progress{Stage: "done"}
+		return nil
+	}
+
+	chunks := make([]vectorstore.Chunk, 0, len(pieces))
+	for i, piece := range pieces {
+		progressCh <- Progress{Stage: "chunk", Chunk: i + 1, Total: len(pieces)}
+		embedding, err := e.Embed(context.Background(), piece)
+		if err != nil {
+			wrapped := fmt.Errorf("failed to embed chunk %d/%d: %v", i+1, len(pieces), err)
+			progressCh <- Progress{Stage: "error", Error: wrapped.Error()}
+			return wrapped
+		}
+		chunks = append(chunks, vectorstore.Chunk{Index: i, Content: piece, Embedding: embedding})
+	}
+
+	// The parent row keeps the first chunk's embedding so non-chunk-aware
+	// callers (e.g. vs.List) still see a representative vector.
+	doc.Embedding = chunks[0].Embedding
+
+	progressCh <- Progress{Stage: "persisting"}
+	if err := chunkedStore.SaveWithChunks(doc, chunks); err != nil {
+		progressCh <- Progress{Stage: "error", Error: err.Error()}
+		return err
+	}
+
+	progressCh <- Progress{Stage: "done"}
+	return nil
+}
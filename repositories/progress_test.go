@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"golang_crud/models"
+	"golang_crud/vectorstore"
+)
+
+// fakeEmbedder is a deterministic Embedder for tests: every text maps to
+// the same fixed-length vector, so no network calls are needed.
+type fakeEmbedder struct{}
+
+func (fakeEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	return []float32{0.1, 0.2, 0.3}, nil
+}
+func (fakeEmbedder) Dimension() int   { return 3 }
+func (fakeEmbedder) Model() string    { return "fake-model" }
+func (fakeEmbedder) Provider() string { return "fake" }
+
+func TestCreateDocumentWithProgressReportsExpectedStageSequence(t *testing.T) {
+	store = vectorstore.NewMemoryStore()
+	emb = fakeEmbedder{}
+	defer func() {
+		store = nil
+		emb = nil
+	}()
+
+	doc := &models.Document{Content: "a short document that fits in a single chunk"}
+	progressCh := make(chan Progress)
+
+	var stages []string
+	done := make(chan error, 1)
+	go func() {
+		done <- CreateDocumentWithProgress(nil, doc, progressCh)
+	}()
+	for p := range progressCh {
+		stages = append(stages, p.Stage)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("CreateDocumentWithProgress returned error: %v", err)
+	}
+
+	want := []string{"queued", "embedding", "persisting", "done"}
+	if len(stages) != len(want) {
+		t.Fatalf("stages = %v, want %v", stages, want)
+	}
+	for i, s := range stages {
+		if s != want[i] {
+			t.Errorf("stage %d = %q, want %q", i, s, want[i])
+		}
+	}
+}
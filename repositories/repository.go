@@ -1,193 +1,163 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
-	"net/http"
 	"fmt"
-	"os"
-	"io"
-	"encoding/json"
-	"bytes"
-	"golang_crud/models"
 	"log"
+	"os"
 	"strconv"
-	"strings"
+
+	"golang_crud/embedder"
+	"golang_crud/models"
+	"golang_crud/textsplitter"
+	"golang_crud/vectorstore"
 )
 
-func getOllamaURL() string {
-    ollamaURL := os.Getenv("OLLAMA_URL")
-    if ollamaURL != "" {
-        return ollamaURL
+// store is the process-wide VectorStore, selected once via
+// VECTOR_STORE_BACKEND so every handler goes through the same backend
+// regardless of which function it calls.
+var store vectorstore.VectorStore
+
+// GetVectorStore lazily initializes and returns the configured VectorStore.
+// db is passed through to the postgres backend; it is ignored by the
+// others.
+func GetVectorStore(db *sql.DB) (vectorstore.VectorStore, error) {
+    if store != nil {
+        return store, nil
     }
-    // Detect Docker environment
-    if _, exists := os.LookupEnv("DOCKER_CONTAINER"); exists {
-        return "http://host.docker.internal:11434"
+
+    s, err := vectorstore.NewFromEnv(db)
+    if err != nil {
+        return nil, err
     }
-    return "http://localhost:11434"
+    store = s
+    return store, nil
 }
 
-// CreateDocument fetches embedding from Ollama and saves to Postgres
-func CreateDocument(db *sql.DB, doc *models.Document) error {
-    // Prepare Ollama request
-    ollamaReq := map[string]string{
-        "model":  "nomic-embed-text",
-        "prompt": doc.Content,
-    }
-    requestBody, err := json.Marshal(ollamaReq)
-    if err != nil {
-        log.Printf("Failed to marshal Ollama request: %v", err)
-        return fmt.Errorf("failed to prepare embedding request: %v", err)
+// emb is the process-wide Embedder, selected once via EMBEDDING_PROVIDER.
+var emb embedder.Embedder
+
+// GetEmbedder lazily initializes and returns the configured Embedder.
+func GetEmbedder() (embedder.Embedder, error) {
+    if emb != nil {
+        return emb, nil
     }
 
-    // Call Ollama
-    endpoint := getOllamaURL() + "/api/embeddings"
-    log.Printf("Sending request to Ollama: %s, URL: %s", string(requestBody), endpoint)
-    resp, err := http.Post(endpoint, "application/json", bytes.NewBuffer(requestBody))
+    e, err := embedder.NewFromEnv()
     if err != nil {
-        log.Printf("Ollama connection error: %v", err)
-        return fmt.Errorf("failed to connect to embedding service: %v", err)
+        return nil, err
     }
-    defer resp.Body.Close()
+    emb = e
+    return emb, nil
+}
 
-    body, err := io.ReadAll(resp.Body)
+// getSplitter builds a textsplitter.Splitter from the CHUNK_SIZE and
+// CHUNK_OVERLAP env vars, falling back to the package defaults.
+func getSplitter() *textsplitter.Splitter {
+    chunkSize, _ := strconv.Atoi(os.Getenv("CHUNK_SIZE"))
+    chunkOverlap, err := strconv.Atoi(os.Getenv("CHUNK_OVERLAP"))
     if err != nil {
-        log.Printf("Error reading Ollama response: %v", err)
-        return fmt.Errorf("failed to read embedding response: %v", err)
+        chunkOverlap = textsplitter.DefaultChunkOverlap
     }
-    log.Printf("Ollama response: status=%d, body=%s", resp.StatusCode, string(body))
+    return textsplitter.New(chunkSize, chunkOverlap)
+}
 
-    if resp.StatusCode != http.StatusOK {
-        log.Printf("Ollama returned non-200 status: %d", resp.StatusCode)
-        return fmt.Errorf("embedding service returned status: %d", resp.StatusCode)
-    }
+// stampEmbeddingMetadata records which provider/model/dimension produced
+// doc's embedding(s), so SearchSimilarDocuments can later refuse to compare
+// vectors from mismatched models.
+func stampEmbeddingMetadata(doc *models.Document, e embedder.Embedder) {
+    doc.EmbeddingProvider = e.Provider()
+    doc.EmbeddingModel = e.Model()
+    doc.EmbeddingDimension = e.Dimension()
+}
 
-    // Parse Ollama response
-    var ollamaResp struct {
-        Embedding []float32 `json:"embedding"`
-    }
-    if err := json.Unmarshal(body, &ollamaResp); err != nil {
-        log.Printf("Failed to parse Ollama response: %v", err)
-        return fmt.Errorf("failed to parse embedding response: %v", err)
+// CreateDocument splits doc.Content into overlapping chunks, embeds each
+// chunk via the configured Embedder, and saves it through the configured
+// VectorStore. When the content fits in a single chunk, or the backend
+// doesn't support chunked storage, it falls back to embedding and saving
+// the whole document.
+func CreateDocument(db *sql.DB, doc *models.Document) error {
+    vs, err := GetVectorStore(db)
+    if err != nil {
+        return fmt.Errorf("failed to get vector store: %v", err)
     }
-    doc.Embedding = ollamaResp.Embedding
-
-    // Insert into database (your provided function)
-    embeddingStr := vectorToString(doc.Embedding)
-    query := `INSERT INTO documents (content, media_type, file_name, embedding) VALUES ($1, $2, $3, $4) RETURNING id, created_at`
-    log.Printf("Executing query: %s", query)
-    log.Printf("Parameters: content=%s, media_type=%s, file_name=%s, embedding_length=%d",
-        doc.Content, doc.MediaType, doc.FileName, len(doc.Embedding))
-    err = db.QueryRow(query, doc.Content, doc.MediaType, doc.FileName, embeddingStr).Scan(&doc.ID, &doc.CreatedAt)
+    e, err := GetEmbedder()
     if err != nil {
-        log.Printf("Database error: %v", err)
-        return fmt.Errorf("failed to save document: %v", err)
+        return fmt.Errorf("failed to get embedder: %v", err)
+    }
+    stampEmbeddingMetadata(doc, e)
+
+    pieces := getSplitter().Split(doc.Content)
+    chunkedStore, supportsChunks := vs.(vectorstore.ChunkedStore)
+
+    if !supportsChunks || len(pieces) <= 1 {
+        embedding, err := e.Embed(context.Background(), doc.Content)
+        if err != nil {
+            return err
+        }
+        doc.Embedding = embedding
+        return vs.Save(doc)
     }
 
-    return nil
-}
-// func CreateDocument(db *sql.DB, doc *models.Document) error {
-// 	embeddingStr := vectorToString(doc.Embedding)
-//
-// 	query := `INSERT INTO documents (content, media_type, file_name, embedding) VALUES ($1, $2, $3, $4) RETURNING id, created_at`
-//
-// 	log.Printf("Executing query: %s", query)
-// 	log.Printf("Parameters: content=%s, media_type=%s, file_name=%s, embedding_length=%d", 
-// 		doc.Content, doc.MediaType, doc.FileName, len(doc.Embedding))
-//
-// 	err := db.QueryRow(query, doc.Content, doc.MediaType, doc.FileName, embeddingStr).Scan(&doc.ID, &doc.CreatedAt)
-// 	if err != nil {
-// 		log.Printf("Database error: %v", err)
-// 	}
-// 	return err
-// }
-
-// Helper function to convert []float32 to pgvector string format
-func vectorToString(embedding []float32) string {
-	strValues := make([]string, len(embedding))
-	for i, v := range embedding {
-		strValues[i] = fmt.Sprintf("%f", v)
-	}
-	return "[" + strings.Join(strValues, ",") + "]"
-}
-
-
-
-// func CreateDocument(db *sql.DB, doc *models.Document) error {
-// 	query := `INSERT INTO documents (content, media_type, file_name, embedding) VALUES ($1, $2, $3, $4) RETURNING id, created_at`
-// 	err := db.QueryRow(query, doc.Content, doc.MediaType, doc.FileName, pq.Array(doc.Embedding)).Scan(&doc.ID, &doc.CreatedAt)
-// 	return err
-// }
-
-
-// func CreateDocument(db *sql.DB, doc *models.Document) error {
-//   query := `INSERT INTO documents (content, embedding) VALUES ($1, $2) RETURNING id, created_at`
-// 	err := db.QueryRow(query, doc.Content, pq.Array(doc.Embedding)).Scan(&doc.ID, &doc.CreatedAt)
-// 	return err
-// }
-
-func SearchSimilarDocuments(db *sql.DB, queryEmbedding []float32, limit int) ([]models.Document, error) {
-	embeddingStr := vectorToString(queryEmbedding)
-
-	query := `
-		SELECT id, content, media_type, file_name, embedding, created_at,
-		       1 - (embedding <=> $1) as similarity
-		FROM documents 
-		ORDER BY embedding <=> $1 
-		LIMIT $2
-	`
+    log.Printf("Splitting document into %d chunks", len(pieces))
+    chunks := make([]vectorstore.Chunk, 0, len(pieces))
+    for i, piece := range pieces {
+        embedding, err := e.Embed(context.Background(), piece)
+        if err != nil {
+            return fmt.Errorf("failed to embed chunk %d/%d: %v", i+1, len(pieces), err)
+        }
+        chunks = append(chunks, vectorstore.Chunk{Index: i, Content: piece, Embedding: embedding})
+    }
 
-	log.Printf("Executing search query with embedding length: %d, limit: %d", len(queryEmbedding), limit)
+    // The parent row keeps the first chunk's embedding so non-chunk-aware
+    // callers (e.g. vs.List) still see a representative vector.
+    doc.Embedding = chunks[0].Embedding
+    return chunkedStore.SaveWithChunks(doc, chunks)
+}
 
-	rows, err := db.Query(query, embeddingStr, limit)
+// SearchSimilarDocuments searches for documents matching queryText/
+// queryEmbedding according to mode:
+//   - "vector" (default): pgvector similarity search, restricted to
+//     documents stored with the current embedder's provider/model.
+//   - "text": full-text (BM25-style) search over document content.
+//   - "hybrid": both searches merged via Reciprocal Rank Fusion.
+//
+// "text" and "hybrid" require a backend that implements the corresponding
+// vectorstore.TextStore/HybridStore capability.
+func SearchSimilarDocuments(db *sql.DB, queryText string, queryEmbedding []float32, mode string, limit int) ([]models.Document, error) {
+	vs, err := GetVectorStore(db)
 	if err != nil {
-		log.Printf("Search query error: %v", err)
-		return nil, err
+		return nil, fmt.Errorf("failed to get vector store: %v", err)
 	}
-	defer rows.Close()
-
-	var documents []models.Document
-	for rows.Next() {
-		var doc models.Document
-		var similarity float64
-		var embeddingStr string
-		var mediaType, fileName sql.NullString
 
-		err := rows.Scan(&doc.ID, &doc.Content, &mediaType, &fileName, &embeddingStr, &doc.CreatedAt, &similarity)
+	switch mode {
+	case "", "vector":
+		e, err := GetEmbedder()
 		if err != nil {
-			log.Printf("Row scan error: %v", err)
-			continue
+			return nil, fmt.Errorf("failed to get embedder: %v", err)
 		}
-
-		if mediaType.Valid {
-			doc.MediaType = &mediaType.String
+		if chunkedStore, ok := vs.(vectorstore.ChunkedStore); ok {
+			return chunkedStore.SearchChunks(queryEmbedding, e.Provider(), e.Model(), limit)
 		}
-		if fileName.Valid {
-			doc.FileName = &fileName.String
+		return vs.SearchMaxSimilarity(queryEmbedding, e.Provider(), e.Model(), limit)
+	case "text":
+		textStore, ok := vs.(vectorstore.TextStore)
+		if !ok {
+			return nil, fmt.Errorf("configured vector store backend does not support text search")
 		}
-		
-		doc.Embedding = parseVectorString(embeddingStr)
-
-		log.Printf("Found document ID %d with similarity: %.4f", doc.ID, similarity)
-		documents = append(documents, doc)
-	}
-
-	return documents, nil
-}
-
-func parseVectorString(vectorSTR string) []float32 {
-	vectorSTR = strings.Trim(vectorSTR, "[]")
-	if vectorSTR == "" {
-		return []float32{}
-	}
-
-	parts := strings.Split(vectorSTR, ",")
-	embedding := make([]float32, len(parts))
-	
-	for i, part := range  parts {
-		if val, err := strconv.ParseFloat(strings.TrimSpace(part), 32); err == nil {
-			embedding[i] = float32(val)
+		return textStore.SearchText(queryText, limit)
+	case "hybrid":
+		hybridStore, ok := vs.(vectorstore.HybridStore)
+		if !ok {
+			return nil, fmt.Errorf("configured vector store backend does not support hybrid search")
 		}
+		e, err := GetEmbedder()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get embedder: %v", err)
+		}
+		return hybridStore.SearchHybrid(queryText, queryEmbedding, e.Provider(), e.Model(), limit)
+	default:
+		return nil, fmt.Errorf("unknown search mode %q", mode)
 	}
-
-	return embedding
 }
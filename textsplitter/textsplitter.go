@@ -0,0 +1,175 @@
+// Package textsplitter breaks long document content into overlapping
+// windows so each piece stays within an embedding model's context limit.
+package textsplitter
+
+import (
+	"strings"
+)
+
+const (
+	// DefaultChunkSize is the target window size in words when the caller
+	// doesn't configure one.
+	DefaultChunkSize = 500
+	// DefaultChunkOverlap is the number of trailing words repeated at the
+	// start of the next chunk so similarity search doesn't lose context at
+	// chunk boundaries.
+	DefaultChunkOverlap = 50
+)
+
+// Splitter divides text into overlapping chunks of at most ChunkSize words,
+// preferring to break on paragraph and sentence boundaries before falling
+// back to a plain word-count window.
+type Splitter struct {
+	ChunkSize    int
+	ChunkOverlap int
+}
+
+// New returns a Splitter with the given chunk size and overlap, in words.
+// A non-positive chunkSize falls back to DefaultChunkSize; a negative
+// chunkOverlap, or one not smaller than chunkSize, falls back to
+// DefaultChunkOverlap. An overlap of 0 is kept.
+func New(chunkSize, chunkOverlap int) *Splitter {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if chunkOverlap < 0 || chunkOverlap >= chunkSize {
+		chunkOverlap = DefaultChunkOverlap
+	}
+	return &Splitter{ChunkSize: chunkSize, ChunkOverlap: chunkOverlap}
+}
+
+// Split breaks text into chunks of at most s.ChunkSize words, each
+// overlapping the previous one by up to s.ChunkOverlap words: the overlap
+// shrinks when the next unit leaves less room, and a unit longer than
+// ChunkSize shares no words with the chunks on either side of it (only its
+// own word windows overlap each other). Paragraph and
+// sentence boundaries are preferred split points; a single run-on paragraph
+// with no punctuation is split purely on word count. Returns a single
+// chunk containing the whole text when it already fits within ChunkSize.
+func (s *Splitter) Split(text string) []string {
+	words := strings.Fields(text)
+	if len(words) <= s.ChunkSize {
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" {
+			return nil
+		}
+		return []string{trimmed}
+	}
+
+	units := splitIntoUnits(text)
+
+	var chunks []string
+	var current []string
+	currentWords := 0
+
+	flush := func() {
+		if len(current) == 0 {
+			return
+		}
+		chunks = append(chunks, strings.TrimSpace(strings.Join(current, " ")))
+	}
+
+	for _, unit := range units {
+		unitWords := strings.Fields(unit)
+
+		if len(unitWords) > s.ChunkSize {
+			flush()
+			chunks = append(chunks, s.splitByWords(unitWords)...)
+			current = nil
+			currentWords = 0
+			continue
+		}
+
+		if currentWords+len(unitWords) > s.ChunkSize {
+			flush()
+			// Carrying the full ChunkOverlap words into the new chunk could
+			// push it past ChunkSize when unitWords is itself large, so cap
+			// the overlap to whatever room unitWords leaves.
+			overlapBudget := s.ChunkSize - len(unitWords)
+			if overlapBudget > s.ChunkOverlap {
+				overlapBudget = s.ChunkOverlap
+			}
+			if overlapBudget < 0 {
+				overlapBudget = 0
+			}
+			overlap := lastWords(current, overlapBudget)
+			current = append(append([]string{}, overlap...), unitWords...)
+			currentWords = len(current)
+			continue
+		}
+
+		current = append(current, unitWords...)
+		currentWords += len(unitWords)
+	}
+	flush()
+
+	return chunks
+}
+
+// splitByWords is the fallback for a single unit (paragraph or sentence)
+// longer than ChunkSize: it windows over raw words with overlap.
+func (s *Splitter) splitByWords(words []string) []string {
+	var chunks []string
+	step := s.ChunkSize - s.ChunkOverlap
+	if step <= 0 {
+		step = s.ChunkSize
+	}
+
+	for start := 0; start < len(words); start += step {
+		end := start + s.ChunkSize
+		if end > len(words) {
+			end = len(words)
+		}
+		chunks = append(chunks, strings.Join(words[start:end], " "))
+		if end == len(words) {
+			break
+		}
+	}
+	return chunks
+}
+
+// splitIntoUnits breaks text into paragraphs, then each paragraph into
+// sentences, preserving order.
+func splitIntoUnits(text string) []string {
+	var units []string
+	for _, para := range strings.Split(text, "\n\n") {
+		para = strings.TrimSpace(para)
+		if para == "" {
+			continue
+		}
+		units = append(units, splitIntoSentences(para)...)
+	}
+	return units
+}
+
+// splitIntoSentences performs a simple sentence split on ".", "!" and "?"
+// followed by whitespace. It's intentionally naive (no abbreviation
+// handling) since it only needs to find reasonable break points.
+func splitIntoSentences(para string) []string {
+	var sentences []string
+	var buf strings.Builder
+
+	runes := []rune(para)
+	for i, r := range runes {
+		buf.WriteRune(r)
+		isBoundary := (r == '.' || r == '!' || r == '?') &&
+			(i+1 == len(runes) || runes[i+1] == ' ' || runes[i+1] == '\n')
+		if isBoundary {
+			sentences = append(sentences, strings.TrimSpace(buf.String()))
+			buf.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(buf.String()); rest != "" {
+		sentences = append(sentences, rest)
+	}
+	return sentences
+}
+
+// lastWords returns the final n words of words (or all of them if there
+// are fewer than n).
+func lastWords(words []string, n int) []string {
+	if n >= len(words) {
+		return words
+	}
+	return words[len(words)-n:]
+}
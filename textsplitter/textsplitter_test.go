@@ -0,0 +1,72 @@
+package textsplitter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitReturnsWholeTextWhenItFits(t *testing.T) {
+	s := New(500, 50)
+	chunks := s.Split("short document")
+	if len(chunks) != 1 || chunks[0] != "short document" {
+		t.Fatalf("Split() = %v, want a single unchanged chunk", chunks)
+	}
+}
+
+func TestSplitOverlapsAcrossChunks(t *testing.T) {
+	s := New(10, 3)
+	words := make([]string, 0, 25)
+	for i := 0; i < 25; i++ {
+		words = append(words, "word")
+	}
+	text := ""
+	for i, w := range words {
+		if i > 0 {
+			text += " "
+		}
+		text += w
+	}
+
+	chunks := s.Split(text)
+	if len(chunks) < 2 {
+		t.Fatalf("expected more than one chunk for 25 words with ChunkSize 10, got %d", len(chunks))
+	}
+	total := 0
+	for _, c := range chunks {
+		got := len(strings.Fields(c))
+		if got > s.ChunkSize {
+			t.Errorf("chunk has %d words, want at most ChunkSize %d", got, s.ChunkSize)
+		}
+		total += got
+	}
+	// Overlap repeats words at chunk boundaries, so the chunks together
+	// hold more words than the input.
+	if total <= len(words) {
+		t.Errorf("chunks hold %d words in all, want more than the %d input words", total, len(words))
+	}
+}
+
+func TestSplitClampsOverlapSoSentenceChunksStayWithinChunkSize(t *testing.T) {
+	s := New(10, 8)
+	// First sentence is short (5 words); the second is large enough (9
+	// words) that carrying the full ChunkOverlap of the first into it would
+	// push the resulting chunk to 14 words, past ChunkSize.
+	text := "one two three four five. six seven eight nine ten eleven twelve thirteen fourteen."
+
+	chunks := s.Split(text)
+	for i, c := range chunks {
+		if got := len(strings.Fields(c)); got > s.ChunkSize {
+			t.Errorf("chunk %d has %d words, want at most ChunkSize %d: %q", i, got, s.ChunkSize, c)
+		}
+	}
+}
+
+func TestNewFallsBackToDefaultsForInvalidInput(t *testing.T) {
+	s := New(0, -1)
+	if s.ChunkSize != DefaultChunkSize {
+		t.Errorf("ChunkSize = %d, want default %d", s.ChunkSize, DefaultChunkSize)
+	}
+	if s.ChunkOverlap != DefaultChunkOverlap {
+		t.Errorf("ChunkOverlap = %d, want default %d", s.ChunkOverlap, DefaultChunkOverlap)
+	}
+}
@@ -0,0 +1,129 @@
+package vectorstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"golang_crud/models"
+)
+
+var documentsBucket = []byte("documents")
+
+// BboltStore is a Bbolt-backed VectorStore for single-node deployments that
+// don't want a Postgres dependency. Documents are stored as JSON values
+// keyed by their stringified ID; similarity search is brute-force cosine
+// similarity, same as MemoryStore.
+type BboltStore struct {
+	db *bbolt.DB
+}
+
+// NewBboltStore opens (creating if necessary) a Bbolt database at path and
+// ensures the documents bucket exists.
+func NewBboltStore(path string) (*BboltStore, error) {
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bbolt store at %s: %v", path, err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(documentsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize bbolt store: %v", err)
+	}
+
+	return &BboltStore{db: db}, nil
+}
+
+func (s *BboltStore) Save(doc *models.Document) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(documentsBucket)
+
+		doc.ID = int(bucket.Stats().KeyN) + 1
+		for {
+			if bucket.Get([]byte(strconv.Itoa(doc.ID))) == nil {
+				break
+			}
+			doc.ID++
+		}
+		doc.CreatedAt = time.Now()
+
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("failed to marshal document: %v", err)
+		}
+		return bucket.Put([]byte(strconv.Itoa(doc.ID)), data)
+	})
+}
+
+func (s *BboltStore) SearchMaxSimilarity(queryEmbedding []float32, provider, model string, limit int) ([]models.Document, error) {
+	all, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	type scored struct {
+		doc        models.Document
+		similarity float64
+	}
+
+	scoredDocs := make([]scored, 0, len(all))
+	for _, doc := range all {
+		if doc.EmbeddingProvider != provider || doc.EmbeddingModel != model || doc.EmbeddingDimension != len(queryEmbedding) {
+			continue
+		}
+		scoredDocs = append(scoredDocs, scored{doc: doc, similarity: cosineSimilarity(queryEmbedding, doc.Embedding)})
+	}
+
+	sort.Slice(scoredDocs, func(i, j int) bool {
+		return scoredDocs[i].similarity > scoredDocs[j].similarity
+	})
+
+	if limit > len(scoredDocs) {
+		limit = len(scoredDocs)
+	}
+
+	documents := make([]models.Document, limit)
+	for i := 0; i < limit; i++ {
+		documents[i] = scoredDocs[i].doc
+	}
+	return documents, nil
+}
+
+func (s *BboltStore) Delete(id int) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(documentsBucket).Delete([]byte(strconv.Itoa(id)))
+	})
+}
+
+func (s *BboltStore) List() ([]models.Document, error) {
+	var documents []models.Document
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(documentsBucket).ForEach(func(k, v []byte) error {
+			var doc models.Document
+			if err := json.Unmarshal(v, &doc); err != nil {
+				return fmt.Errorf("failed to unmarshal document %s: %v", k, err)
+			}
+			documents = append(documents, doc)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(documents, func(i, j int) bool { return documents[i].ID < documents[j].ID })
+	return documents, nil
+}
+
+// Close releases the underlying Bbolt file handle.
+func (s *BboltStore) Close() error {
+	return s.db.Close()
+}
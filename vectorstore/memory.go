@@ -0,0 +1,112 @@
+package vectorstore
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"golang_crud/models"
+)
+
+// MemoryStore is an in-memory VectorStore keyed by document ID. Similarity
+// search is brute-force cosine similarity over every stored document, which
+// is fine for small collections and local development without Postgres.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	nextID int
+	byID   map[int]models.Document
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{byID: make(map[int]models.Document)}
+}
+
+func (s *MemoryStore) Save(doc *models.Document) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	doc.ID = s.nextID
+	doc.CreatedAt = time.Now()
+	s.byID[doc.ID] = *doc
+	return nil
+}
+
+func (s *MemoryStore) SearchMaxSimilarity(queryEmbedding []float32, provider, model string, limit int) ([]models.Document, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	type scored struct {
+		doc        models.Document
+		similarity float64
+	}
+
+	scoredDocs := make([]scored, 0, len(s.byID))
+	for _, doc := range s.byID {
+		if doc.EmbeddingProvider != provider || doc.EmbeddingModel != model || doc.EmbeddingDimension != len(queryEmbedding) {
+			continue
+		}
+		scoredDocs = append(scoredDocs, scored{doc: doc, similarity: cosineSimilarity(queryEmbedding, doc.Embedding)})
+	}
+
+	sort.Slice(scoredDocs, func(i, j int) bool {
+		return scoredDocs[i].similarity > scoredDocs[j].similarity
+	})
+
+	if limit > len(scoredDocs) {
+		limit = len(scoredDocs)
+	}
+
+	documents := make([]models.Document, limit)
+	for i := 0; i < limit; i++ {
+		documents[i] = scoredDocs[i].doc
+	}
+	return documents, nil
+}
+
+func (s *MemoryStore) Delete(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.byID[id]; !ok {
+		return fmt.Errorf("document %d not found", id)
+	}
+	delete(s.byID, id)
+	return nil
+}
+
+func (s *MemoryStore) List() ([]models.Document, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	documents := make([]models.Document, 0, len(s.byID))
+	for _, doc := range s.byID {
+		documents = append(documents, doc)
+	}
+	sort.Slice(documents, func(i, j int) bool { return documents[i].ID < documents[j].ID })
+	return documents, nil
+}
+
+// cosineSimilarity returns the cosine similarity between a and b, or 0 if
+// either vector has zero magnitude or they differ in length.
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
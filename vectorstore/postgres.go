@@ -0,0 +1,442 @@
+package vectorstore
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang_crud/models"
+)
+
+// rrfK is the reciprocal rank fusion damping constant recommended by the
+// original RRF paper; it keeps a single high rank in one list from
+// dominating the fused score.
+const rrfK = 60
+
+// PostgresStore is the pgvector-backed VectorStore. It is the original
+// storage implementation used by this project before other backends were
+// added.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore wraps an existing database connection as a VectorStore.
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Save(doc *models.Document) error {
+	embeddingStr := vectorToString(doc.Embedding)
+	query := `INSERT INTO documents (content, media_type, file_name, embedding, embedding_provider, embedding_model, embedding_dimension)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, created_at`
+	log.Printf("Executing query: %s", query)
+	log.Printf("Parameters: content=%s, media_type=%s, file_name=%s, embedding_length=%d",
+		doc.Content, doc.MediaType, doc.FileName, len(doc.Embedding))
+
+	err := s.db.QueryRow(query, doc.Content, doc.MediaType, doc.FileName, embeddingStr,
+		doc.EmbeddingProvider, doc.EmbeddingModel, doc.EmbeddingDimension).Scan(&doc.ID, &doc.CreatedAt)
+	if err != nil {
+		log.Printf("Database error: %v", err)
+		return fmt.Errorf("failed to save document: %v", err)
+	}
+	return nil
+}
+
+// SearchMaxSimilarity restricts the search to documents whose stored
+// embedding_provider/embedding_model/embedding_dimension match
+// provider/model and queryEmbedding's own length, so it never ranks
+// vectors produced by a different embedding model -- including a same
+// provider/model pair whose dimension changed (e.g. OLLAMA_EMBED_DIM
+// edited without renaming the model).
+func (s *PostgresStore) SearchMaxSimilarity(queryEmbedding []float32, provider, model string, limit int) ([]models.Document, error) {
+	embeddingStr := vectorToString(queryEmbedding)
+
+	query := `
+		SELECT id, content, media_type, file_name, embedding, created_at,
+		       1 - (embedding <=> $1) as similarity
+		FROM documents
+		WHERE embedding_provider = $2 AND embedding_model = $3 AND embedding_dimension = $4
+		ORDER BY embedding <=> $1
+		LIMIT $5
+	`
+
+	log.Printf("Executing search query with embedding length: %d, provider=%s, model=%s, limit: %d", len(queryEmbedding), provider, model, limit)
+
+	rows, err := s.db.Query(query, embeddingStr, provider, model, len(queryEmbedding), limit)
+	if err != nil {
+		log.Printf("Search query error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var documents []models.Document
+	for rows.Next() {
+		var doc models.Document
+		var similarity float64
+		var embeddingStr string
+		var mediaType, fileName sql.NullString
+
+		err := rows.Scan(&doc.ID, &doc.Content, &mediaType, &fileName, &embeddingStr, &doc.CreatedAt, &similarity)
+		if err != nil {
+			log.Printf("Row scan error: %v", err)
+			continue
+		}
+
+		if mediaType.Valid {
+			doc.MediaType = &mediaType.String
+		}
+		if fileName.Valid {
+			doc.FileName = &fileName.String
+		}
+
+		doc.Embedding = parseVectorString(embeddingStr)
+
+		log.Printf("Found document ID %d with similarity: %.4f", doc.ID, similarity)
+		documents = append(documents, doc)
+	}
+
+	return documents, nil
+}
+
+func (s *PostgresStore) Delete(id int) error {
+	_, err := s.db.Exec(`DELETE FROM documents WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete document %d: %v", id, err)
+	}
+	return nil
+}
+
+// SearchText ranks documents by full-text relevance to queryText using the
+// generated content_tsv column.
+func (s *PostgresStore) SearchText(queryText string, limit int) ([]models.Document, error) {
+	query := `
+		SELECT id, content, media_type, file_name, embedding, created_at,
+		       ts_rank(content_tsv, plainto_tsquery('english', $1)) as rank
+		FROM documents
+		WHERE content_tsv @@ plainto_tsquery('english', $1)
+		ORDER BY rank DESC
+		LIMIT $2
+	`
+
+	log.Printf("Executing text search query: %q, limit: %d", queryText, limit)
+
+	rows, err := s.db.Query(query, queryText, limit)
+	if err != nil {
+		log.Printf("Text search query error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var documents []models.Document
+	for rows.Next() {
+		var doc models.Document
+		var rank float64
+		var embeddingStr string
+		var mediaType, fileName sql.NullString
+
+		err := rows.Scan(&doc.ID, &doc.Content, &mediaType, &fileName, &embeddingStr, &doc.CreatedAt, &rank)
+		if err != nil {
+			log.Printf("Row scan error: %v", err)
+			continue
+		}
+
+		if mediaType.Valid {
+			doc.MediaType = &mediaType.String
+		}
+		if fileName.Valid {
+			doc.FileName = &fileName.String
+		}
+		doc.Embedding = parseVectorString(embeddingStr)
+
+		documents = append(documents, doc)
+	}
+
+	return documents, nil
+}
+
+// SearchHybrid runs a vector similarity search and a full-text search over
+// a wider candidate set, then merges the two ranked lists with Reciprocal
+// Rank Fusion: each document's score is the sum of 1/(rrfK+rank) over every
+// list it appears in, with rank counted from 1. This typically outperforms either search alone for
+// keyword-heavy queries.
+func (s *PostgresStore) SearchHybrid(queryText string, queryEmbedding []float32, provider, model string, limit int) ([]models.Document, error) {
+	candidateLimit := limit * 4
+	if candidateLimit < 20 {
+		candidateLimit = 20
+	}
+
+	vectorHits, err := s.SearchMaxSimilarity(queryEmbedding, provider, model, candidateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid search: vector query failed: %v", err)
+	}
+
+	textHits, err := s.SearchText(queryText, candidateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("hybrid search: text query failed: %v", err)
+	}
+
+	fusedDocs := fuseRankedLists(vectorHits, textHits)
+
+	if limit > len(fusedDocs) {
+		limit = len(fusedDocs)
+	}
+	documents := make([]models.Document, limit)
+	for i := 0; i < limit; i++ {
+		documents[i] = fusedDocs[i].doc
+	}
+	return documents, nil
+}
+
+// fusedDoc pairs a document with its combined Reciprocal Rank Fusion score.
+type fusedDoc struct {
+	doc   models.Document
+	score float64
+}
+
+// fuseRankedLists merges any number of ranked result lists with Reciprocal
+// Rank Fusion and returns them sorted by descending score. A document
+// appearing in multiple lists accumulates a score contribution from each;
+// rank is each list's own 0-based position, independent of the others. It
+// has no database dependency so it's covered directly by unit tests.
+func fuseRankedLists(lists ...[]models.Document) []fusedDoc {
+	byID := make(map[int]*fusedDoc)
+	for _, docs := range lists {
+		for rank, doc := range docs {
+			f, ok := byID[doc.ID]
+			if !ok {
+				f = &fusedDoc{doc: doc}
+				byID[doc.ID] = f
+			}
+			f.score += 1.0 / float64(rrfK+rank+1)
+		}
+	}
+
+	fused := make([]fusedDoc, 0, len(byID))
+	for _, f := range byID {
+		fused = append(fused, *f)
+	}
+	sort.Slice(fused, func(i, j int) bool { return fused[i].score > fused[j].score })
+	return fused
+}
+
+// SaveBatch inserts all of docs with a single multi-row INSERT inside one
+// transaction, which is far cheaper than one QueryRow per document when
+// ingesting a large batch. It writes documents only, never document_chunks
+// -- batch-ingested documents rely on SearchChunks' un-chunked-parent
+// fallback to remain searchable, the same as any document saved via Save.
+func (s *PostgresStore) SaveBatch(docs []*models.Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	var sb strings.Builder
+	args := make([]interface{}, 0, len(docs)*7)
+	sb.WriteString("INSERT INTO documents (content, media_type, file_name, embedding, embedding_provider, embedding_model, embedding_dimension) VALUES ")
+	for i, doc := range docs {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		base := i * 7
+		fmt.Fprintf(&sb, "($%d, $%d, $%d, $%d, $%d, $%d, $%d)", base+1, base+2, base+3, base+4, base+5, base+6, base+7)
+		args = append(args, doc.Content, doc.MediaType, doc.FileName, vectorToString(doc.Embedding),
+			doc.EmbeddingProvider, doc.EmbeddingModel, doc.EmbeddingDimension)
+	}
+	sb.WriteString(" RETURNING id, created_at")
+
+	rows, err := tx.Query(sb.String(), args...)
+	if err != nil {
+		return fmt.Errorf("failed to insert document batch: %v", err)
+	}
+
+	i := 0
+	for rows.Next() {
+		if i >= len(docs) {
+			break
+		}
+		if err := rows.Scan(&docs[i].ID, &docs[i].CreatedAt); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan inserted document %d: %v", i, err)
+		}
+		i++
+	}
+	rows.Close()
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit document batch: %v", err)
+	}
+	return nil
+}
+
+// SaveWithChunks inserts doc as a parent row and each of chunks into
+// document_chunks, linked by the parent's new id, all in one transaction.
+func (s *PostgresStore) SaveWithChunks(doc *models.Document, chunks []Chunk) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	embeddingStr := vectorToString(doc.Embedding)
+	query := `INSERT INTO documents (content, media_type, file_name, embedding, embedding_provider, embedding_model, embedding_dimension)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, created_at`
+	err = tx.QueryRow(query, doc.Content, doc.MediaType, doc.FileName, embeddingStr,
+		doc.EmbeddingProvider, doc.EmbeddingModel, doc.EmbeddingDimension).Scan(&doc.ID, &doc.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save document: %v", err)
+	}
+
+	chunkQuery := `INSERT INTO document_chunks (parent_id, chunk_index, content, embedding) VALUES ($1, $2, $3, $4)`
+	for _, chunk := range chunks {
+		_, err := tx.Exec(chunkQuery, doc.ID, chunk.Index, chunk.Content, vectorToString(chunk.Embedding))
+		if err != nil {
+			return fmt.Errorf("failed to save chunk %d of document %d: %v", chunk.Index, doc.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit document and chunks: %v", err)
+	}
+	return nil
+}
+
+// SearchChunks finds the document_chunks rows closest to queryEmbedding,
+// restricted to parent documents stored with the given provider/model and
+// whose embedding_dimension matches queryEmbedding's own length (chunks
+// inherit their parent's embedding_dimension, stamped once before
+// splitting), aggregates them by parent document using the best (max)
+// similarity per parent, and returns up to limit parent documents ordered
+// by that score. Documents short enough to have been saved without chunks
+// (via Save or SaveBatch) have no document_chunks rows at all, so they're
+// unioned in separately, scored directly against their own
+// documents.embedding -- otherwise the inner join below would make them
+// invisible to search.
+func (s *PostgresStore) SearchChunks(queryEmbedding []float32, provider, model string, limit int) ([]models.Document, error) {
+	embeddingStr := vectorToString(queryEmbedding)
+	dimension := len(queryEmbedding)
+
+	query := `
+		SELECT id, content, media_type, file_name, embedding, created_at, max_similarity FROM (
+			SELECT d.id, d.content, d.media_type, d.file_name, d.embedding, d.created_at,
+			       MAX(1 - (c.embedding <=> $1)) as max_similarity
+			FROM document_chunks c
+			JOIN documents d ON d.id = c.parent_id
+			WHERE d.embedding_provider = $2 AND d.embedding_model = $3 AND d.embedding_dimension = $4
+			GROUP BY d.id
+
+			UNION ALL
+
+			SELECT d.id, d.content, d.media_type, d.file_name, d.embedding, d.created_at,
+			       1 - (d.embedding <=> $1) as max_similarity
+			FROM documents d
+			WHERE d.embedding_provider = $2 AND d.embedding_model = $3 AND d.embedding_dimension = $4
+			  AND NOT EXISTS (SELECT 1 FROM document_chunks c WHERE c.parent_id = d.id)
+		) combined
+		ORDER BY max_similarity DESC
+		LIMIT $5
+	`
+
+	log.Printf("Executing chunked search query with embedding length: %d, provider=%s, model=%s, limit: %d", len(queryEmbedding), provider, model, limit)
+
+	rows, err := s.db.Query(query, embeddingStr, provider, model, dimension, limit)
+	if err != nil {
+		log.Printf("Chunked search query error: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var documents []models.Document
+	for rows.Next() {
+		var doc models.Document
+		var maxSimilarity float64
+		var embeddingStr string
+		var mediaType, fileName sql.NullString
+
+		err := rows.Scan(&doc.ID, &doc.Content, &mediaType, &fileName, &embeddingStr, &doc.CreatedAt, &maxSimilarity)
+		if err != nil {
+			log.Printf("Row scan error: %v", err)
+			continue
+		}
+
+		if mediaType.Valid {
+			doc.MediaType = &mediaType.String
+		}
+		if fileName.Valid {
+			doc.FileName = &fileName.String
+		}
+
+		doc.Embedding = parseVectorString(embeddingStr)
+
+		log.Printf("Found document ID %d with max chunk similarity: %.4f", doc.ID, maxSimilarity)
+		documents = append(documents, doc)
+	}
+
+	return documents, nil
+}
+
+func (s *PostgresStore) List() ([]models.Document, error) {
+	rows, err := s.db.Query(`SELECT id, content, media_type, file_name, embedding, created_at FROM documents`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var documents []models.Document
+	for rows.Next() {
+		var doc models.Document
+		var embeddingStr string
+		var mediaType, fileName sql.NullString
+
+		if err := rows.Scan(&doc.ID, &doc.Content, &mediaType, &fileName, &embeddingStr, &doc.CreatedAt); err != nil {
+			log.Printf("Row scan error: %v", err)
+			continue
+		}
+
+		if mediaType.Valid {
+			doc.MediaType = &mediaType.String
+		}
+		if fileName.Valid {
+			doc.FileName = &fileName.String
+		}
+		doc.Embedding = parseVectorString(embeddingStr)
+
+		documents = append(documents, doc)
+	}
+
+	return documents, nil
+}
+
+// vectorToString converts []float32 to pgvector's text format.
+func vectorToString(embedding []float32) string {
+	strValues := make([]string, len(embedding))
+	for i, v := range embedding {
+		strValues[i] = fmt.Sprintf("%f", v)
+	}
+	return "[" + strings.Join(strValues, ",") + "]"
+}
+
+func parseVectorString(vectorSTR string) []float32 {
+	vectorSTR = strings.Trim(vectorSTR, "[]")
+	if vectorSTR == "" {
+		return []float32{}
+	}
+
+	parts := strings.Split(vectorSTR, ",")
+	embedding := make([]float32, len(parts))
+
+	for i, part := range parts {
+		if val, err := strconv.ParseFloat(strings.TrimSpace(part), 32); err == nil {
+			embedding[i] = float32(val)
+		}
+	}
+
+	return embedding
+}
@@ -0,0 +1,64 @@
+package vectorstore
+
+import (
+	"testing"
+
+	"golang_crud/models"
+)
+
+func TestFuseRankedListsOrdersByReciprocalRank(t *testing.T) {
+	vectorHits := []models.Document{{ID: 1}, {ID: 2}, {ID: 3}}
+	textHits := []models.Document{{ID: 2}, {ID: 3}, {ID: 1}}
+
+	fused := fuseRankedLists(vectorHits, textHits)
+	if len(fused) != 3 {
+		t.Fatalf("expected 3 fused documents, got %d", len(fused))
+	}
+
+	// Doc 2 ranks #2 in vector (1/62) and #1 in text (1/61), the best
+	// combined score, so it should come out on top.
+	if fused[0].doc.ID != 2 {
+		t.Errorf("expected doc 2 to rank first, got doc %d", fused[0].doc.ID)
+	}
+
+	wantScore := func(vectorRank, textRank int) float64 {
+		return 1.0/float64(rrfK+vectorRank+1) + 1.0/float64(rrfK+textRank+1)
+	}
+	scores := map[int]float64{}
+	for _, f := range fused {
+		scores[f.doc.ID] = f.score
+	}
+	if got, want := scores[1], wantScore(0, 2); got != want {
+		t.Errorf("doc 1 score = %v, want %v", got, want)
+	}
+	if got, want := scores[2], wantScore(1, 0); got != want {
+		t.Errorf("doc 2 score = %v, want %v", got, want)
+	}
+	if got, want := scores[3], wantScore(2, 1); got != want {
+		t.Errorf("doc 3 score = %v, want %v", got, want)
+	}
+}
+
+func TestFuseRankedListsDeduplicatesByID(t *testing.T) {
+	fused := fuseRankedLists(
+		[]models.Document{{ID: 1}},
+		[]models.Document{{ID: 1}},
+	)
+	if len(fused) != 1 {
+		t.Fatalf("expected duplicate IDs to merge into 1 document, got %d", len(fused))
+	}
+	want := 2.0 / float64(rrfK+1)
+	if fused[0].score != want {
+		t.Errorf("merged score = %v, want %v", fused[0].score, want)
+	}
+}
+
+func TestFuseRankedListsHandlesOnlyOneList(t *testing.T) {
+	fused := fuseRankedLists([]models.Document{{ID: 5}, {ID: 9}})
+	if len(fused) != 2 {
+		t.Fatalf("expected 2 fused documents, got %d", len(fused))
+	}
+	if fused[0].doc.ID != 5 || fused[1].doc.ID != 9 {
+		t.Errorf("expected rank order preserved for a single list, got ids %d, %d", fused[0].doc.ID, fused[1].doc.ID)
+	}
+}
@@ -0,0 +1,215 @@
+package vectorstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang_crud/models"
+)
+
+const (
+	redisDocPrefix  = "doc:"
+	redisIndexName  = "idx:documents"
+	redisVectorAttr = "embedding"
+)
+
+// RedisStore stores documents as Redis hashes and searches them with
+// RediSearch's HNSW vector index. It requires the redisearch module to be
+// loaded on the target Redis instance.
+type RedisStore struct {
+	client *redis.Client
+	ctx    context.Context
+	dim    int
+}
+
+// NewRedisStore connects to addr and ensures the RediSearch HNSW index
+// exists, creating it if necessary. dim sizes the index's vector field and
+// must match the dimension of whichever Embedder is configured, since
+// RediSearch (unlike pgvector) can't store vectors of varying length in one
+// field -- callers should pass embedder.Embedder.Dimension() rather than a
+// separately configured constant, so the two can never drift apart.
+func NewRedisStore(addr string, dim int) (*RedisStore, error) {
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	ctx := context.Background()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis at %s: %v", addr, err)
+	}
+	if dim <= 0 {
+		return nil, fmt.Errorf("vectorstore: redis backend requires a positive embedding dimension, got %d", dim)
+	}
+
+	store := &RedisStore{client: client, ctx: ctx, dim: dim}
+	if err := store.ensureIndex(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func (s *RedisStore) ensureIndex() error {
+	_, err := s.client.Do(s.ctx, "FT.INFO", redisIndexName).Result()
+	if err == nil {
+		return nil
+	}
+
+	_, err = s.client.Do(s.ctx, "FT.CREATE", redisIndexName,
+		"ON", "HASH", "PREFIX", "1", redisDocPrefix,
+		"SCHEMA",
+		redisVectorAttr, "VECTOR", "HNSW", "6", "TYPE", "FLOAT32", "DIM", strconv.Itoa(s.dim), "DISTANCE_METRIC", "COSINE",
+	).Result()
+	if err != nil {
+		return fmt.Errorf("failed to create redisearch index: %v", err)
+	}
+	return nil
+}
+
+func (s *RedisStore) Save(doc *models.Document) error {
+	id, err := s.client.Incr(s.ctx, "documents:next_id").Result()
+	if err != nil {
+		return fmt.Errorf("failed to allocate document id: %v", err)
+	}
+	doc.ID = int(id)
+	doc.CreatedAt = time.Now()
+
+	metadata, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal document: %v", err)
+	}
+
+	key := redisDocPrefix + strconv.Itoa(doc.ID)
+	err = s.client.HSet(s.ctx, key, map[string]interface{}{
+		redisVectorAttr: floatsToBytes(doc.Embedding),
+		"metadata":       metadata,
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to save document %d: %v", doc.ID, err)
+	}
+	return nil
+}
+
+// SearchMaxSimilarity runs the HNSW KNN query over a wider candidate set
+// than limit, then filters to documents matching provider/model and
+// truncates. The index schema has no TAG field for provider/model, so this
+// can't be pushed into the KNN query itself without a schema change.
+func (s *RedisStore) SearchMaxSimilarity(queryEmbedding []float32, provider, model string, limit int) ([]models.Document, error) {
+	candidateLimit := limit * 5
+	query := fmt.Sprintf("*=>[KNN %d @%s $vec AS score]", candidateLimit, redisVectorAttr)
+
+	result, err := s.client.Do(s.ctx, "FT.SEARCH", redisIndexName, query,
+		"PARAMS", "2", "vec", floatsToBytes(queryEmbedding),
+		"SORTBY", "score",
+		"LIMIT", "0", strconv.Itoa(candidateLimit),
+		"DIALECT", "2",
+	).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redisearch query failed: %v", err)
+	}
+
+	candidates, err := parseSearchResults(result)
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []models.Document
+	for _, doc := range candidates {
+		if doc.EmbeddingProvider != provider || doc.EmbeddingModel != model || doc.EmbeddingDimension != len(queryEmbedding) {
+			continue
+		}
+		documents = append(documents, doc)
+		if len(documents) == limit {
+			break
+		}
+	}
+	return documents, nil
+}
+
+func (s *RedisStore) Delete(id int) error {
+	key := redisDocPrefix + strconv.Itoa(id)
+	n, err := s.client.Del(s.ctx, key).Result()
+	if err != nil {
+		return fmt.Errorf("failed to delete document %d: %v", id, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("document %d not found", id)
+	}
+	return nil
+}
+
+func (s *RedisStore) List() ([]models.Document, error) {
+	var documents []models.Document
+	var cursor uint64
+
+	for {
+		keys, next, err := s.client.Scan(s.ctx, cursor, redisDocPrefix+"*", 100).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan documents: %v", err)
+		}
+
+		for _, key := range keys {
+			raw, err := s.client.HGet(s.ctx, key, "metadata").Result()
+			if err != nil {
+				continue
+			}
+			var doc models.Document
+			if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+				continue
+			}
+			documents = append(documents, doc)
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return documents, nil
+}
+
+// floatsToBytes encodes embedding as RediSearch's expected little-endian
+// FLOAT32 blob format.
+func floatsToBytes(embedding []float32) []byte {
+	buf := make([]byte, 4*len(embedding))
+	for i, v := range embedding {
+		bits := math.Float32bits(v)
+		buf[i*4] = byte(bits)
+		buf[i*4+1] = byte(bits >> 8)
+		buf[i*4+2] = byte(bits >> 16)
+		buf[i*4+3] = byte(bits >> 24)
+	}
+	return buf
+}
+
+// parseSearchResults converts the raw FT.SEARCH reply into documents,
+// skipping the RediSearch metadata fields it doesn't recognize.
+func parseSearchResults(result interface{}) ([]models.Document, error) {
+	rows, ok := result.([]interface{})
+	if !ok || len(rows) == 0 {
+		return nil, nil
+	}
+
+	var documents []models.Document
+	for i := 1; i < len(rows); i += 2 {
+		fields, ok := rows[i+1].([]interface{})
+		if !ok {
+			continue
+		}
+		for j := 0; j+1 < len(fields); j += 2 {
+			name, _ := fields[j].(string)
+			if name != "metadata" {
+				continue
+			}
+			raw, _ := fields[j+1].(string)
+			var doc models.Document
+			if err := json.Unmarshal([]byte(raw), &doc); err == nil {
+				documents = append(documents, doc)
+			}
+		}
+	}
+	return documents, nil
+}
@@ -0,0 +1,130 @@
+package vectorstore
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+
+	"golang_crud/embedder"
+	"golang_crud/models"
+)
+
+// VectorStore abstracts the persistence and similarity search of document
+// embeddings so that handlers/repositories do not need to know whether the
+// backing store is Postgres, an in-memory map, Bbolt, or Redis.
+type VectorStore interface {
+	// Save persists doc, setting doc.ID and doc.CreatedAt on success.
+	Save(doc *models.Document) error
+	// SearchMaxSimilarity returns up to limit documents ordered by
+	// descending similarity to queryEmbedding. Only documents whose stored
+	// embedding_provider/embedding_model match provider/model, and whose
+	// embedding_dimension matches queryEmbedding's own length, are
+	// considered, so vectors from different embedding models -- or the
+	// same model reconfigured to a different dimension -- are never
+	// compared against each other.
+	SearchMaxSimilarity(queryEmbedding []float32, provider, model string, limit int) ([]models.Document, error)
+	// Delete removes the document with the given id.
+	Delete(id int) error
+	// List returns every document currently in the store.
+	List() ([]models.Document, error)
+}
+
+// Chunk is one embedded window of a larger document's content, produced by
+// the textsplitter package.
+type Chunk struct {
+	Index     int
+	Content   string
+	Embedding []float32
+}
+
+// ChunkedStore is an optional capability implemented by backends that can
+// store a document as multiple embedded chunks and search over them,
+// aggregating hits back to the parent document. Callers should type-assert
+// a VectorStore to ChunkedStore and fall back to the plain VectorStore
+// methods when unsupported.
+type ChunkedStore interface {
+	VectorStore
+
+	// SaveWithChunks persists doc (setting its ID and CreatedAt) along with
+	// its chunks, linked to doc's new ID.
+	SaveWithChunks(doc *models.Document, chunks []Chunk) error
+	// SearchChunks searches chunk embeddings restricted to provider/model
+	// and queryEmbedding's own dimension, and returns up to limit parent
+	// documents, ordered by the best-matching chunk's similarity.
+	SearchChunks(queryEmbedding []float32, provider, model string, limit int) ([]models.Document, error)
+}
+
+// BatchStore is an optional capability implemented by backends that can
+// persist many documents in a single round trip. Callers should type-assert
+// a VectorStore to BatchStore and fall back to calling Save in a loop when
+// unsupported.
+type BatchStore interface {
+	VectorStore
+
+	// SaveBatch persists docs, setting each doc's ID and CreatedAt on
+	// success. It either saves all of docs or none.
+	SaveBatch(docs []*models.Document) error
+}
+
+// TextStore is an optional capability implemented by backends that can run
+// a full-text (BM25-style) search over document content.
+type TextStore interface {
+	VectorStore
+
+	// SearchText returns up to limit documents ranked by full-text
+	// relevance to queryText.
+	SearchText(queryText string, limit int) ([]models.Document, error)
+}
+
+// HybridStore is an optional capability implemented by backends that can
+// combine full-text and vector search results using Reciprocal Rank Fusion.
+type HybridStore interface {
+	VectorStore
+
+	// SearchHybrid merges a full-text search for queryText with a vector
+	// search for queryEmbedding (restricted to provider/model) via RRF, and
+	// returns up to limit documents.
+	SearchHybrid(queryText string, queryEmbedding []float32, provider, model string, limit int) ([]models.Document, error)
+}
+
+// NewFromEnv builds the VectorStore selected by the VECTOR_STORE_BACKEND
+// environment variable. Supported values are "postgres" (default), "memory",
+// "bbolt" and "redis". db is only used by the postgres backend and may be
+// nil for the others.
+func NewFromEnv(db *sql.DB) (VectorStore, error) {
+	backend := os.Getenv("VECTOR_STORE_BACKEND")
+	if backend == "" {
+		backend = "postgres"
+	}
+
+	switch backend {
+	case "postgres":
+		if db == nil {
+			return nil, fmt.Errorf("vectorstore: postgres backend requires a *sql.DB")
+		}
+		return NewPostgresStore(db), nil
+	case "memory":
+		return NewMemoryStore(), nil
+	case "bbolt":
+		path := os.Getenv("BBOLT_PATH")
+		if path == "" {
+			path = "vectorstore.db"
+		}
+		return NewBboltStore(path)
+	case "redis":
+		addr := os.Getenv("REDIS_ADDR")
+		if addr == "" {
+			addr = "localhost:6379"
+		}
+		// The RediSearch index's vector field has a fixed width, so it must
+		// be sized from the same Embedder the rest of the app uses rather
+		// than a separately configured constant that could drift from it.
+		e, err := embedder.NewFromEnv()
+		if err != nil {
+			return nil, fmt.Errorf("vectorstore: failed to resolve embedder for redis backend: %v", err)
+		}
+		return NewRedisStore(addr, e.Dimension())
+	default:
+		return nil, fmt.Errorf("vectorstore: unknown VECTOR_STORE_BACKEND %q", backend)
+	}
+}